@@ -0,0 +1,15 @@
+//go:build !windows
+// +build !windows
+
+package fsutils
+
+import "os"
+
+// doChown applies uid/gid ownership to path, following symlinks unless
+// followSymlinks is false
+func doChown(path string, uid, gid int, followSymlinks bool) error {
+	if !followSymlinks {
+		return os.Lchown(path, uid, gid)
+	}
+	return os.Chown(path, uid, gid)
+}
@@ -0,0 +1,39 @@
+//go:build !windows
+// +build !windows
+
+package fsutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChownTreeFollowSymlinksFalseUsesLchown(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target")
+	if err := os.WriteFile(target, []byte("hi"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatalf("Symlink: %v", err)
+	}
+
+	uid, gid := os.Getuid(), os.Getgid()
+
+	// With followSymlinks false, the link itself is chowned via os.Lchown
+	// rather than its target.
+	if err := ChownTree(dir, uid, gid, false); err != nil {
+		t.Fatalf("ChownTree(followSymlinks=false): %v", err)
+	}
+
+	// Once the target is gone only os.Lchown can succeed on the link;
+	// os.Chown would fail trying to reach the missing target.
+	if err := os.Remove(target); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := ChownTree(dir, uid, gid, false); err != nil {
+		t.Fatalf("ChownTree(followSymlinks=false) with a dangling link: %v", err)
+	}
+}
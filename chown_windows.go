@@ -0,0 +1,11 @@
+//go:build windows
+// +build windows
+
+package fsutils
+
+// doChown is a no-op on Windows, which has no POSIX uid/gid ownership
+// model. MkdirAs and ChownTree still create/visit paths normally; only the
+// ownership step is skipped.
+func doChown(path string, uid, gid int, followSymlinks bool) error {
+	return nil
+}
@@ -0,0 +1,29 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+// +build darwin dragonfly freebsd netbsd openbsd
+
+package fsutils
+
+import (
+	"syscall"
+	"time"
+)
+
+// AccessTime returns the time the path was last accessed, or the zero Time
+// if the backing fs.FS does not expose one
+func (s SystemPath) AccessTime() time.Time {
+	st, ok := s.stat.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(int64(st.Atimespec.Sec), int64(st.Atimespec.Nsec))
+}
+
+// ChangeTime returns the time the path's inode metadata was last changed,
+// or the zero Time if the backing fs.FS does not expose one
+func (s SystemPath) ChangeTime() time.Time {
+	st, ok := s.stat.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(int64(st.Ctimespec.Sec), int64(st.Ctimespec.Nsec))
+}
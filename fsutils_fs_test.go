@@ -0,0 +1,58 @@
+package fsutils
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestSystemInitFS(t *testing.T) {
+	mapfs := fstest.MapFS{
+		"dir":          {Mode: 0755 | fs.ModeDir},
+		"dir/file.txt": {Data: []byte("hello"), Mode: 0644},
+	}
+
+	file := SystemInitFS(mapfs, "dir/file.txt")
+	if file.HaveError() {
+		t.Fatalf("HaveError() = true, err: %v", file.Error())
+	}
+	if file.IsDir() {
+		t.Error("IsDir() = true for a regular file")
+	}
+	if file.Size() != 5 {
+		t.Errorf("Size() = %d, want 5", file.Size())
+	}
+
+	dir := SystemInitFS(mapfs, "dir")
+	if !dir.IsDir() {
+		t.Error("IsDir() = false for a directory")
+	}
+}
+
+func TestSystemInitFSGetUIDUnsupported(t *testing.T) {
+	mapfs := fstest.MapFS{
+		"file.txt": {Data: []byte("hello"), Mode: 0644},
+	}
+
+	sp := SystemInitFS(mapfs, "file.txt")
+
+	if _, err := sp.GetUID(); !errors.Is(err, ErrUnsupportedFS) {
+		t.Errorf("GetUID() err = %v, want ErrUnsupportedFS", err)
+	}
+	if _, err := sp.GetGID(); !errors.Is(err, ErrUnsupportedFS) {
+		t.Errorf("GetGID() err = %v, want ErrUnsupportedFS", err)
+	}
+}
+
+func TestSystemInitFSMissingPath(t *testing.T) {
+	mapfs := fstest.MapFS{}
+
+	sp := SystemInitFS(mapfs, "missing.txt")
+	if !sp.HaveError() {
+		t.Error("HaveError() = false for a path absent from the fs.FS")
+	}
+	if sp.IsExist() {
+		t.Error("IsExist() = true for a path absent from the fs.FS")
+	}
+}
@@ -0,0 +1,29 @@
+//go:build linux
+// +build linux
+
+package fsutils
+
+import (
+	"syscall"
+	"time"
+)
+
+// AccessTime returns the time the path was last accessed, or the zero Time
+// if the backing fs.FS does not expose one
+func (s SystemPath) AccessTime() time.Time {
+	st, ok := s.stat.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(int64(st.Atim.Sec), int64(st.Atim.Nsec))
+}
+
+// ChangeTime returns the time the path's inode metadata was last changed,
+// or the zero Time if the backing fs.FS does not expose one
+func (s SystemPath) ChangeTime() time.Time {
+	st, ok := s.stat.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(int64(st.Ctim.Sec), int64(st.Ctim.Nsec))
+}
@@ -0,0 +1,176 @@
+//go:build !windows
+// +build !windows
+
+package fsutils
+
+import (
+	"errors"
+	"syscall"
+)
+
+/*InitUser get The current active user
+
+* It holds the process's real and effective uid/gid and its supplementary
+  groups. No error is returned; if the supplementary groups cannot be read,
+  the list is left empty.
+*/
+func InitUser() UserDetails {
+	var user UserDetails
+	user.uid = syscall.Getuid()
+	user.gid = syscall.Getgid()
+	user.euid = syscall.Geteuid()
+	user.egid = syscall.Getegid()
+
+	// Getgroups can fail on some platforms/sandboxes; fall back to no
+	// supplementary groups rather than propagating the error, since callers
+	// only ever consult the primary uid/gid in that case.
+	groups, err := syscall.Getgroups()
+	if err == nil {
+		user.groups = groups
+	}
+
+	return user
+}
+
+// GetUID returns the path user id or an error
+func (s SystemPath) GetUID() (uint32, error) {
+	st, ok := s.stat.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, ErrUnsupportedFS
+	}
+
+	if st.Uid >= 0 {
+		return st.Uid, nil
+	}
+
+	return 0, errors.New("Invalid value for uid")
+}
+
+// GetGID returns the path group id or an error
+func (s SystemPath) GetGID() (uint32, error) {
+	st, ok := s.stat.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, ErrUnsupportedFS
+	}
+
+	if st.Gid >= 0 {
+		return st.Gid, nil
+	}
+
+	return 0, errors.New("Invalid value for gid")
+}
+
+// Inode returns the path's inode number, or 0 if the backing fs.FS does not
+// expose one
+func (s SystemPath) Inode() uint64 {
+	st, ok := s.stat.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0
+	}
+	return uint64(st.Ino)
+}
+
+// Device returns the id of the device containing the path, or 0 if the
+// backing fs.FS does not expose one
+func (s SystemPath) Device() uint64 {
+	st, ok := s.stat.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0
+	}
+	return uint64(st.Dev)
+}
+
+// NLink returns the number of hard links to the path, or 0 if the backing
+// fs.FS does not expose one
+func (s SystemPath) NLink() uint64 {
+	st, ok := s.stat.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0
+	}
+	return uint64(st.Nlink)
+}
+
+// isInGroup check whether gid matches the user's primary gid or any of its
+// supplementary groups
+func isInGroup(gid uint32, usergid int, groups []int) bool {
+	if gid == uint32(usergid) {
+		return true
+	}
+
+	for _, g := range groups {
+		if gid == uint32(g) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsReadable check if the current user has read permission to a path
+func (s SystemPath) IsReadable() bool {
+	user := InitUser()
+
+	if user.GetEUid() == 0 {
+		return true
+	}
+
+	useruid := user.GetUID()
+	fileuid, _ := s.GetUID()
+	filegid, _ := s.GetGID()
+
+	if fileuid == uint32(useruid) {
+		return s.IsOwnerReadable()
+	}
+
+	if isInGroup(filegid, user.GetGID(), user.GetGroups()) {
+		return s.IsGroupReadable()
+	}
+
+	return s.IsOtherReadable()
+}
+
+// IsWriteable check if the current user have write permission to a path
+func (s SystemPath) IsWriteable() bool {
+	user := InitUser()
+
+	if user.GetEUid() == 0 {
+		return true
+	}
+
+	useruid := user.GetUID()
+	fileuid, _ := s.GetUID()
+	filegid, _ := s.GetGID()
+
+	if fileuid == uint32(useruid) {
+		return s.IsOwnerWriteable()
+	}
+
+	if isInGroup(filegid, user.GetGID(), user.GetGroups()) {
+		return s.IsGroupWriteable()
+	}
+
+	return s.IsOtherWriteable()
+}
+
+// IsExecutible check if a path have execution permission for the user
+func (s SystemPath) IsExecutible() bool {
+	user := InitUser()
+
+	if user.GetEUid() == 0 {
+		return s.HavePerm(IXUSR) || s.HavePerm(IXGRP) || s.HavePerm(IXOTH)
+	}
+
+	useruid := user.GetUID()
+	fileuid, _ := s.GetUID()
+	filegid, _ := s.GetGID()
+
+	if fileuid == uint32(useruid) {
+		return s.IsOwnerExecutable()
+	}
+
+	if isInGroup(filegid, user.GetGID(), user.GetGroups()) {
+		return s.IsGroupExecutable()
+	}
+
+	return s.IsOtherExecutable()
+}
@@ -0,0 +1,67 @@
+//go:build !windows
+// +build !windows
+
+package fsutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsInGroup(t *testing.T) {
+	cases := []struct {
+		name    string
+		gid     uint32
+		usergid int
+		groups  []int
+		want    bool
+	}{
+		{"matches primary gid", 100, 100, nil, true},
+		{"matches a supplementary group", 200, 100, []int{50, 200, 300}, true},
+		{"matches none", 200, 100, []int{50, 300}, false},
+		{"empty supplementary groups", 200, 100, []int{}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := isInGroup(c.gid, c.usergid, c.groups); got != c.want {
+				t.Errorf("isInGroup(%d, %d, %v) = %v, want %v", c.gid, c.usergid, c.groups, got, c.want)
+			}
+		})
+	}
+}
+
+func TestIsReadableRootShortCircuit(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("requires root to exercise the euid==0 short-circuit")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "locked")
+
+	if err := os.WriteFile(path, []byte("secret"), 0000); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	sp := SystemInit(path)
+	if !sp.IsReadable() {
+		t.Error("IsReadable() = false for root, want true regardless of file mode")
+	}
+	if !sp.IsWriteable() {
+		t.Error("IsWriteable() = false for root, want true regardless of file mode")
+	}
+
+	// Root's execute short-circuit mirrors kernel semantics: it only
+	// succeeds if some execute bit is set, not unconditionally.
+	if sp.IsExecutible() {
+		t.Error("IsExecutible() = true for a 000 file, want false since no execute bit is set")
+	}
+	if err := os.Chmod(path, 0100); err != nil {
+		t.Fatalf("Chmod: %v", err)
+	}
+	sp = SystemInit(path)
+	if !sp.IsExecutible() {
+		t.Error("IsExecutible() = false for root with an owner-execute bit set, want true")
+	}
+}
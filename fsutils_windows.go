@@ -0,0 +1,238 @@
+//go:build windows
+// +build windows
+
+package fsutils
+
+import (
+	"errors"
+	"os"
+	"os/user"
+	"strconv"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// ErrNoOwnerSID is returned when a path's owning SID could not be resolved
+// to a numeric id understood by the rest of the package
+var ErrNoOwnerSID = errors.New("fsutils: unable to resolve owner SID")
+
+/*InitUser get The current active user
+
+* Windows has no POSIX uid/gid pair, so we fall back to the relative
+  identifier (RID) embedded in the user's SID. Supplementary groups are
+  left empty since group membership is evaluated via the security
+  descriptor directly rather than a gid list.
+*/
+func InitUser() UserDetails {
+	var details UserDetails
+
+	u, err := user.Current()
+	if err != nil {
+		return details
+	}
+
+	if uid, err := sidRID(u.Uid); err == nil {
+		details.uid = uid
+		details.euid = uid
+	}
+
+	if gid, err := sidRID(u.Gid); err == nil {
+		details.gid = gid
+		details.egid = gid
+	}
+
+	return details
+}
+
+// sidRID extracts the relative identifier from a textual SID, which we use
+// as a stand-in for a POSIX uid/gid since Windows has no native equivalent
+func sidRID(sid string) (int, error) {
+	s, err := windows.StringToSid(sid)
+	if err != nil {
+		return 0, err
+	}
+
+	rid := s.SubAuthority(uint32(s.SubAuthorityCount()) - 1)
+	return strconv.Atoi(strconv.FormatUint(uint64(rid), 10))
+}
+
+// ownerSID returns the owning SID of a path's security descriptor
+func ownerSID(path string) (*windows.SID, error) {
+	sd, err := windows.GetNamedSecurityInfo(
+		path,
+		windows.SE_FILE_OBJECT,
+		windows.OWNER_SECURITY_INFORMATION,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, _, err := sd.Owner()
+	if err != nil {
+		return nil, err
+	}
+
+	return owner, nil
+}
+
+// GetUID returns the path owner's relative identifier in place of a POSIX
+// uid, ErrUnsupportedFS if s is backed by an fs.FS rather than the real
+// filesystem, or ErrNoOwnerSID if the owning SID could not be resolved
+func (s SystemPath) GetUID() (uint32, error) {
+	if s.fsys != nil {
+		return 0, ErrUnsupportedFS
+	}
+
+	sid, err := ownerSID(s.path)
+	if err != nil {
+		return 0, ErrNoOwnerSID
+	}
+
+	rid, err := sidRID(sid.String())
+	if err != nil {
+		return 0, ErrNoOwnerSID
+	}
+
+	return uint32(rid), nil
+}
+
+// GetGID returns the path owning group's relative identifier in place of a
+// POSIX gid, ErrUnsupportedFS if s is backed by an fs.FS rather than the
+// real filesystem, or ErrNoOwnerSID if it could not be resolved
+func (s SystemPath) GetGID() (uint32, error) {
+	if s.fsys != nil {
+		return 0, ErrUnsupportedFS
+	}
+
+	sd, err := windows.GetNamedSecurityInfo(
+		s.path,
+		windows.SE_FILE_OBJECT,
+		windows.GROUP_SECURITY_INFORMATION,
+	)
+	if err != nil {
+		return 0, ErrNoOwnerSID
+	}
+
+	group, _, err := sd.Group()
+	if err != nil {
+		return 0, ErrNoOwnerSID
+	}
+
+	rid, err := sidRID(group.String())
+	if err != nil {
+		return 0, ErrNoOwnerSID
+	}
+
+	return uint32(rid), nil
+}
+
+// IsReadable check if the current user has read permission to a path, by
+// probing it with os.OpenFile since Windows ACLs have no owner/group/other
+// bits to compare against a uid/gid the way POSIX does
+func (s SystemPath) IsReadable() bool {
+	f, err := os.OpenFile(s.path, os.O_RDONLY, 0)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+}
+
+// IsWriteable check if the current user have write permission to a path, by
+// probing it with os.OpenFile
+func (s SystemPath) IsWriteable() bool {
+	f, err := os.OpenFile(s.path, os.O_WRONLY, 0)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+}
+
+// IsExecutible check if a path have execution permission for the user.
+// Windows has no execute bit, so any file the user can read is considered
+// executable by the OS loader
+func (s SystemPath) IsExecutible() bool {
+	return s.IsReadable()
+}
+
+// fileByHandleInfo opens the path and reads its
+// BY_HANDLE_FILE_INFORMATION, which is where Windows keeps the file index
+// (our inode stand-in), volume serial number (our device stand-in) and
+// link count
+func fileByHandleInfo(path string) (*windows.ByHandleFileInformation, error) {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := windows.CreateFile(
+		p,
+		windows.GENERIC_READ,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE,
+		nil,
+		windows.OPEN_EXISTING,
+		windows.FILE_FLAG_BACKUP_SEMANTICS,
+		0,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer windows.CloseHandle(h)
+
+	var info windows.ByHandleFileInformation
+	if err := windows.GetFileInformationByHandle(h, &info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// AccessTime returns the time the path was last accessed
+func (s SystemPath) AccessTime() time.Time {
+	info, err := fileByHandleInfo(s.path)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(0, info.LastAccessTime.Nanoseconds())
+}
+
+// ChangeTime returns the time the path's metadata was last changed. Windows
+// has no equivalent of the POSIX ctime, so the creation time is returned
+// instead as the closest available approximation
+func (s SystemPath) ChangeTime() time.Time {
+	info, err := fileByHandleInfo(s.path)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(0, info.CreationTime.Nanoseconds())
+}
+
+// Inode returns the file index reported by the NTFS volume in place of a
+// POSIX inode number
+func (s SystemPath) Inode() uint64 {
+	info, err := fileByHandleInfo(s.path)
+	if err != nil {
+		return 0
+	}
+	return uint64(info.FileIndexHigh)<<32 | uint64(info.FileIndexLow)
+}
+
+// Device returns the volume serial number in place of a POSIX device id
+func (s SystemPath) Device() uint64 {
+	info, err := fileByHandleInfo(s.path)
+	if err != nil {
+		return 0
+	}
+	return uint64(info.VolumeSerialNumber)
+}
+
+// NLink returns the number of hard links to the path
+func (s SystemPath) NLink() uint64 {
+	info, err := fileByHandleInfo(s.path)
+	if err != nil {
+		return 0
+	}
+	return uint64(info.NumberOfLinks)
+}
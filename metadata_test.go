@@ -0,0 +1,131 @@
+package fsutils
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestModTime(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+
+	if err := os.WriteFile(path, []byte("hi"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	want, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+
+	got := SystemInit(path).ModTime()
+	if !got.Equal(want.ModTime()) {
+		t.Errorf("ModTime() = %v, want %v", got, want.ModTime())
+	}
+}
+
+func TestAccessAndChangeTime(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+
+	if err := os.WriteFile(path, []byte("hi"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	sp := SystemInit(path)
+
+	if at := sp.AccessTime(); at.IsZero() {
+		t.Error("AccessTime() returned the zero Time for a freshly-written file")
+	}
+	if ct := sp.ChangeTime(); ct.IsZero() {
+		t.Error("ChangeTime() returned the zero Time for a freshly-written file")
+	}
+}
+
+func TestAccessTimeUnsupportedFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"file.txt": &fstest.MapFile{Data: []byte("hi")},
+	}
+
+	sp := SystemInitFS(fsys, "file.txt")
+	if at := sp.AccessTime(); !at.IsZero() {
+		t.Errorf("AccessTime() = %v for an fs.FS-backed path, want the zero Time", at)
+	}
+	if ct := sp.ChangeTime(); !ct.IsZero() {
+		t.Errorf("ChangeTime() = %v for an fs.FS-backed path, want the zero Time", ct)
+	}
+}
+
+func TestInodeDeviceNLink(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+
+	if err := os.WriteFile(path, []byte("hi"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	sp := SystemInit(path)
+
+	if sp.Inode() == 0 {
+		t.Error("Inode() = 0 for a real file")
+	}
+	if sp.NLink() != 1 {
+		t.Errorf("NLink() = %d, want 1 for a freshly-created file", sp.NLink())
+	}
+
+	link := filepath.Join(dir, "hardlink.txt")
+	if err := os.Link(path, link); err != nil {
+		t.Skipf("hard links not supported here: %v", err)
+	}
+
+	if got := SystemInit(path).NLink(); got != 2 {
+		t.Errorf("NLink() = %d after adding a hard link, want 2", got)
+	}
+	if SystemInit(link).Inode() != sp.Inode() {
+		t.Error("Inode() differs between a file and its hard link, want them equal")
+	}
+	if SystemInit(link).Device() != sp.Device() {
+		t.Error("Device() differs between a file and its hard link, want them equal")
+	}
+}
+
+func TestOwnerAndGroupName(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+
+	if err := os.WriteFile(path, []byte("hi"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	me, err := user.Current()
+	if err != nil {
+		t.Skipf("user.Current: %v", err)
+	}
+
+	sp := SystemInit(path)
+
+	owner, err := sp.OwnerName()
+	if err != nil {
+		t.Fatalf("OwnerName: %v", err)
+	}
+	if owner != me.Username {
+		t.Errorf("OwnerName() = %q, want %q", owner, me.Username)
+	}
+
+	// A second lookup should hit the name cache and still return the same
+	// value.
+	owner2, err := sp.OwnerName()
+	if err != nil {
+		t.Fatalf("OwnerName (cached): %v", err)
+	}
+	if owner2 != owner {
+		t.Errorf("OwnerName() on cache hit = %q, want %q", owner2, owner)
+	}
+
+	if _, err := sp.GroupName(); err != nil {
+		t.Fatalf("GroupName: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package fsutils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// MkdirError reports a failure to create or take ownership of a directory.
+// Use errors.Is(err, os.ErrExist) or errors.Is(err, os.ErrPermission) against
+// it to tell "already exists" apart from a permission failure.
+type MkdirError struct {
+	Path string
+	Err  error
+}
+
+func (e *MkdirError) Error() string {
+	return fmt.Sprintf("mkdir %s: %v", e.Path, e.Err)
+}
+
+func (e *MkdirError) Unwrap() error {
+	return e.Err
+}
+
+// ChownError reports a failure to change ownership of a path while walking
+// a tree. Use errors.Is(err, os.ErrPermission) to detect a permission
+// failure.
+type ChownError struct {
+	Path string
+	Err  error
+}
+
+func (e *ChownError) Error() string {
+	return fmt.Sprintf("chown %s: %v", e.Path, e.Err)
+}
+
+func (e *ChownError) Unwrap() error {
+	return e.Err
+}
+
+// missingAncestors returns path and every ancestor of it that does not yet
+// exist, ordered from path up to (but not including) the first existing
+// ancestor
+func missingAncestors(path string) []string {
+	var missing []string
+
+	cur := path
+	for {
+		if SystemInit(cur).IsExist() {
+			break
+		}
+
+		missing = append(missing, cur)
+
+		parent := filepath.Dir(cur)
+		if parent == cur {
+			break
+		}
+		cur = parent
+	}
+
+	return missing
+}
+
+// MkdirAs creates path owned by uid/gid. When mkAll is true it behaves like
+// os.MkdirAll: any missing ancestor directories are created too, but only
+// the components that did not already exist are chowned to uid/gid -
+// pre-existing parents are left untouched. This matches what's needed when
+// preparing a rootfs-like tree for a different user than the caller.
+//
+// On Windows, where there is no POSIX uid/gid ownership model, the
+// directories are still created; only the ownership step is a no-op (see
+// doChown).
+func MkdirAs(path string, mode os.FileMode, uid, gid int, mkAll bool) error {
+	if !mkAll {
+		if err := os.Mkdir(path, mode); err != nil {
+			return &MkdirError{Path: path, Err: err}
+		}
+		if err := doChown(path, uid, gid, true); err != nil {
+			return &MkdirError{Path: path, Err: err}
+		}
+		return nil
+	}
+
+	missing := missingAncestors(path)
+
+	if err := os.MkdirAll(path, mode); err != nil {
+		return &MkdirError{Path: path, Err: err}
+	}
+
+	for _, p := range missing {
+		if err := doChown(p, uid, gid, true); err != nil {
+			return &MkdirError{Path: p, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// ChownTree recursively changes the owner of every entry under root to
+// uid/gid. When followSymlinks is false, symbolic links are chowned
+// themselves via os.Lchown rather than having their target changed.
+//
+// On Windows this still walks the tree (surfacing a walk error such as a
+// missing root), but doChown is a no-op since there is no POSIX uid/gid
+// ownership model to apply.
+func ChownTree(root string, uid, gid int, followSymlinks bool) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if err := doChown(path, uid, gid, followSymlinks); err != nil {
+			return &ChownError{Path: path, Err: err}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,112 @@
+package fsutils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMkdirAsSingleLevel(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "child")
+
+	uid, gid := os.Getuid(), os.Getgid()
+	if err := MkdirAs(path, 0755, uid, gid, false); err != nil {
+		t.Fatalf("MkdirAs: %v", err)
+	}
+
+	if !SystemInit(path).IsDir() {
+		t.Error("MkdirAs did not create a directory at path")
+	}
+}
+
+func TestMkdirAsSingleLevelExists(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "child")
+
+	if err := os.Mkdir(path, 0755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+
+	err := MkdirAs(path, 0755, os.Getuid(), os.Getgid(), false)
+	if err == nil {
+		t.Fatal("MkdirAs on an existing path = nil error, want one wrapping os.ErrExist")
+	}
+	if !errors.Is(err, os.ErrExist) {
+		t.Errorf("MkdirAs error = %v, want errors.Is(err, os.ErrExist)", err)
+	}
+
+	var mkdirErr *MkdirError
+	if !errors.As(err, &mkdirErr) {
+		t.Errorf("MkdirAs error = %T, want *MkdirError", err)
+	}
+}
+
+func TestMkdirAsMkAllOnlyChownsMissing(t *testing.T) {
+	dir := t.TempDir()
+	parent := filepath.Join(dir, "parent")
+	if err := os.Mkdir(parent, 0755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+
+	path := filepath.Join(parent, "a", "b", "c")
+
+	// MkdirAs chowns exactly these components, so the existing parent must
+	// not be among them.
+	missing := missingAncestors(path)
+	if len(missing) != 3 || missing[len(missing)-1] != filepath.Join(parent, "a") {
+		t.Fatalf("missingAncestors(%q) = %v, want c, b and a but not the existing parent", path, missing)
+	}
+
+	uid, gid := os.Getuid(), os.Getgid()
+	if err := MkdirAs(path, 0755, uid, gid, true); err != nil {
+		t.Fatalf("MkdirAs: %v", err)
+	}
+
+	if !SystemInit(path).IsDir() {
+		t.Error("MkdirAs(mkAll=true) did not create the leaf directory")
+	}
+	if !SystemInit(filepath.Join(parent, "a")).IsDir() {
+		t.Error("MkdirAs(mkAll=true) did not create a missing ancestor")
+	}
+}
+
+func TestMkdirAsMkAllAllExist(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a", "b")
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	if err := MkdirAs(path, 0755, os.Getuid(), os.Getgid(), true); err != nil {
+		t.Fatalf("MkdirAs on an all-existing tree: %v", err)
+	}
+}
+
+func TestChownTree(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "sub")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	file := filepath.Join(sub, "file.txt")
+	if err := os.WriteFile(file, []byte("hi"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	uid, gid := os.Getuid(), os.Getgid()
+	if err := ChownTree(dir, uid, gid, true); err != nil {
+		t.Fatalf("ChownTree: %v", err)
+	}
+}
+
+func TestChownTreeMissingRoot(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "does-not-exist")
+
+	err := ChownTree(missing, os.Getuid(), os.Getgid(), true)
+	if err == nil {
+		t.Fatal("ChownTree on a missing root = nil error, want one reporting the walk failure")
+	}
+}
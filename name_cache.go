@@ -0,0 +1,79 @@
+package fsutils
+
+import (
+	"container/list"
+	"strconv"
+	"sync"
+)
+
+// idNameCacheSize bounds how many uid/gid -> name lookups we keep around;
+// large tree walks can touch thousands of distinct files but typically only
+// a handful of distinct owners
+const idNameCacheSize = 256
+
+// idNameCache is a small LRU cache mapping a numeric id to a resolved name,
+// used to avoid repeated NSS lookups (os/user.LookupId, LookupGroupId) when
+// walking large trees
+type idNameCache struct {
+	mu    sync.Mutex
+	cap   int
+	items map[string]*list.Element
+	order *list.List
+}
+
+type idNameEntry struct {
+	key   string
+	value string
+}
+
+func newIDNameCache(capacity int) *idNameCache {
+	return &idNameCache{
+		cap:   capacity,
+		items: make(map[string]*list.Element, capacity),
+		order: list.New(),
+	}
+}
+
+func (c *idNameCache) get(id uint32) (string, bool) {
+	key := strconv.FormatUint(uint64(id), 10)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return "", false
+	}
+
+	c.order.MoveToFront(el)
+	return el.Value.(*idNameEntry).value, true
+}
+
+func (c *idNameCache) set(id uint32, name string) {
+	key := strconv.FormatUint(uint64(id), 10)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*idNameEntry).value = name
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&idNameEntry{key: key, value: name})
+	c.items[key] = el
+
+	if c.order.Len() > c.cap {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*idNameEntry).key)
+		}
+	}
+}
+
+var (
+	ownerNameCache = newIDNameCache(idNameCacheSize)
+	groupNameCache = newIDNameCache(idNameCacheSize)
+)
@@ -0,0 +1,102 @@
+//go:build !windows
+// +build !windows
+
+package fsutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadLinkAndFollowSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.txt")
+	link := filepath.Join(dir, "link")
+
+	if err := os.WriteFile(target, []byte("hi"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatalf("Symlink: %v", err)
+	}
+
+	sp := SystemInitNoFollow(link)
+	if !sp.IsSymlink() {
+		t.Fatal("IsSymlink() = false for a symlink")
+	}
+
+	got, err := sp.ReadLink()
+	if err != nil {
+		t.Fatalf("ReadLink: %v", err)
+	}
+	if got != target {
+		t.Errorf("ReadLink() = %q, want %q", got, target)
+	}
+
+	resolved, err := sp.FollowSymlink()
+	if err != nil {
+		t.Fatalf("FollowSymlink: %v", err)
+	}
+	if resolved.IsSymlink() {
+		t.Error("FollowSymlink() result is still a symlink")
+	}
+	if resolved.IsDir() {
+		t.Error("FollowSymlink() result reports as a directory, want a regular file")
+	}
+}
+
+func TestReadLinkOnNonSymlink(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plain.txt")
+	if err := os.WriteFile(path, []byte("hi"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	sp := SystemInitNoFollow(path)
+	if _, err := sp.ReadLink(); err == nil {
+		t.Error("ReadLink() on a non-symlink returned nil error, want an error")
+	}
+}
+
+func TestResolveAllFollowsChain(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.txt")
+	middle := filepath.Join(dir, "middle")
+	link := filepath.Join(dir, "link")
+
+	if err := os.WriteFile(target, []byte("hi"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.Symlink(target, middle); err != nil {
+		t.Fatalf("Symlink: %v", err)
+	}
+	if err := os.Symlink(middle, link); err != nil {
+		t.Fatalf("Symlink: %v", err)
+	}
+
+	resolved, err := SystemInitNoFollow(link).ResolveAll(10)
+	if err != nil {
+		t.Fatalf("ResolveAll: %v", err)
+	}
+	if resolved.IsSymlink() {
+		t.Error("ResolveAll() result is still a symlink")
+	}
+}
+
+func TestResolveAllDetectsLoop(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a")
+	b := filepath.Join(dir, "b")
+
+	if err := os.Symlink(b, a); err != nil {
+		t.Fatalf("Symlink: %v", err)
+	}
+	if err := os.Symlink(a, b); err != nil {
+		t.Fatalf("Symlink: %v", err)
+	}
+
+	if _, err := SystemInitNoFollow(a).ResolveAll(10); err == nil {
+		t.Error("ResolveAll() on a symlink loop returned nil error, want an error")
+	}
+}
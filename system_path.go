@@ -2,14 +2,25 @@ package fsutils
 
 import (
 	"errors"
+	"io/fs"
 	"os"
-	"syscall"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"time"
 )
 
+// ErrUnsupportedFS is returned when a SystemPath is backed by an fs.FS that
+// does not expose POSIX owner information (e.g. fstest.MapFS, or an entry
+// inside a tar/zip archive), so GetUID/GetGID have nothing to read
+var ErrUnsupportedFS = errors.New("fsutils: underlying fs.FS does not expose owner information")
+
 // SystemPath represents a struct with private members that stores information about a path
 type SystemPath struct {
-	stat os.FileInfo
+	path string
+	stat fs.FileInfo
 	err  error
+	fsys fs.FS
 }
 
 const (
@@ -42,7 +53,25 @@ const (
 // SystemInit get a path and create a SystemPath with it
 func SystemInit(path string) SystemPath {
 	stat, err := os.Stat(path)
-	return SystemPath{stat, err}
+	return SystemPath{path: path, stat: stat, err: err}
+}
+
+// SystemInitNoFollow get a path and create a SystemPath with it without
+// following a final symbolic link, so IsSymlink() and the permission
+// predicates reflect the link itself rather than its target
+func SystemInitNoFollow(path string) SystemPath {
+	stat, err := os.Lstat(path)
+	return SystemPath{path: path, stat: stat, err: err}
+}
+
+// SystemInitFS get a path rooted in fsys and create a SystemPath with it,
+// so permission logic can be exercised against an fstest.MapFS, an
+// embed.FS, or a file inside a tar/zip archive without touching the real
+// disk. GetUID/GetGID return ErrUnsupportedFS for such paths since fs.FS
+// carries no POSIX owner information.
+func SystemInitFS(fsys fs.FS, path string) SystemPath {
+	stat, err := fs.Stat(fsys, path)
+	return SystemPath{path: path, stat: stat, err: err, fsys: fsys}
 }
 
 // HaveError check to see if an error was returned
@@ -188,89 +217,123 @@ func (s SystemPath) IsOtherExecutable() bool {
 	return s.HavePerm(IXOTH)
 }
 
-// GetUID returns the path user id or an error
-func (s SystemPath) GetUID() (uint32, error) {
-	uid := s.stat.Sys().(*syscall.Stat_t).Uid
-	if uid >= 0 {
-		return uid, nil
-	}
+// Size return length in bytes for regular files; system-dependent for others
+func (s SystemPath) Size() int64 {
+	return s.stat.Size()
+}
 
-	return 0, errors.New("Invalid value for uid")
+// ModTime returns the time the path's content was last modified
+func (s SystemPath) ModTime() time.Time {
+	return s.stat.ModTime()
 }
 
-// GetGID returns the path group id or an error
-func (s SystemPath) GetGID() (uint32, error) {
-	gid := s.stat.Sys().(*syscall.Stat_t).Uid
-	if gid >= 0 {
-		return gid, nil
+// OwnerName resolves the path's owner uid to a user name via os/user,
+// caching the result so repeated lookups while walking a tree don't each
+// pay for an NSS round trip
+func (s SystemPath) OwnerName() (string, error) {
+	uid, err := s.GetUID()
+	if err != nil {
+		return "", err
 	}
 
-	return 0, errors.New("Invalid value for gid")
-}
+	if name, ok := ownerNameCache.get(uid); ok {
+		return name, nil
+	}
 
-// IsReadable check if the current user has read permission to a path
-func (s SystemPath) IsReadable() bool {
-	user := InitUser()
+	u, err := user.LookupId(strconv.FormatUint(uint64(uid), 10))
+	if err != nil {
+		return "", err
+	}
 
-	useruid := user.GetUID()
-	usergid := user.GetGID()
-	fileuid, _ := s.GetUID()
-	filegid, _ := s.GetGID()
+	ownerNameCache.set(uid, u.Username)
+	return u.Username, nil
+}
 
-	if fileuid == uint32(useruid) {
-		return s.IsOwnerReadable()
+// GroupName resolves the path's owning gid to a group name via os/user,
+// caching the result so repeated lookups while walking a tree don't each
+// pay for an NSS round trip
+func (s SystemPath) GroupName() (string, error) {
+	gid, err := s.GetGID()
+	if err != nil {
+		return "", err
 	}
 
-	if filegid == uint32(usergid) {
-		return s.IsGroupReadable()
+	if name, ok := groupNameCache.get(gid); ok {
+		return name, nil
 	}
 
-	return s.IsOtherReadable()
-}
+	g, err := user.LookupGroupId(strconv.FormatUint(uint64(gid), 10))
+	if err != nil {
+		return "", err
+	}
 
-// IsWriteable check if the current user have write permission to a path
-func (s SystemPath) IsWriteable() bool {
-	user := InitUser()
+	groupNameCache.set(gid, g.Name)
+	return g.Name, nil
+}
 
-	useruid := user.GetUID()
-	usergid := user.GetGID()
-	fileuid, _ := s.GetUID()
-	filegid, _ := s.GetGID()
+// ReadLink return the target of a symbolic link, or an error if the path is
+// not a symlink or could not be read
+func (s SystemPath) ReadLink() (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
 
-	if fileuid == uint32(useruid) {
-		return s.IsOwnerWriteable()
+	if s.fsys != nil {
+		return "", ErrUnsupportedFS
 	}
 
-	if filegid == uint32(usergid) {
-		return s.IsGroupWriteable()
+	if !s.IsSymlink() {
+		return "", errors.New("path is not a symbolic link")
 	}
 
-	return s.IsOtherWriteable()
+	return os.Readlink(s.path)
 }
 
-// IsExecutible check if a path have execution permission for the user
-func (s SystemPath) IsExecutible() bool {
-	user := InitUser()
-
-	useruid := user.GetUID()
-	usergid := user.GetGID()
-	fileuid, _ := s.GetUID()
-	filegid, _ := s.GetGID()
-
-	if fileuid == uint32(useruid) {
-		return s.IsOwnerExecutable()
+// FollowSymlink resolve a single symbolic link hop, returning a SystemPath
+// for the link's target
+func (s SystemPath) FollowSymlink() (SystemPath, error) {
+	target, err := s.ReadLink()
+	if err != nil {
+		return SystemPath{path: s.path, err: err}, err
 	}
 
-	if filegid == uint32(usergid) {
-		return s.IsGroupExecutable()
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(s.path), target)
 	}
 
-	return s.IsOtherExecutable()
+	return SystemInitNoFollow(target), nil
 }
 
-// Size return length in bytes for regular files; system-dependent for others
-func (s SystemPath) Size() int64 {
-	return s.stat.Size()
+// ResolveAll walks a chain of symbolic links starting at s, following up to
+// maxHops hops and returning the final, non-symlink SystemPath. It returns
+// an error if a link is broken, unreadable, or the chain does not terminate
+// within maxHops (which also guards against symlink loops).
+func (s SystemPath) ResolveAll(maxHops int) (SystemPath, error) {
+	current := s
+
+	for hops := 0; ; hops++ {
+		if current.HaveError() {
+			return SystemPath{path: current.path, err: current.Error()}, current.Error()
+		}
+
+		if !current.IsSymlink() {
+			return current, nil
+		}
+
+		if hops == maxHops {
+			break
+		}
+
+		next, err := current.FollowSymlink()
+		if err != nil {
+			return SystemPath{path: current.path, err: err}, err
+		}
+
+		current = next
+	}
+
+	err := errors.New("too many levels of symbolic links")
+	return SystemPath{path: current.path, err: err}, err
 }
 
 // GetCurrentDir returns the working directory that the executable points to
@@ -1,30 +1,12 @@
-package fsutil
-
-import (
-	"syscall"
-)
+package fsutils
 
 // UserDetails contains private members regarding the current user in the system
 type UserDetails struct {
-	uid  int
-	gid  int
-	euid int
-	egid int
-}
-
-/*InitUser get The current active user
-
-* If the user was not found, or something went wrong, we will return error
-  and empty UserDetails.
-*/
-func InitUser() UserDetails {
-	var user UserDetails
-	user.uid = syscall.Getuid()
-	user.gid = syscall.Getgid()
-	user.euid = syscall.Geteuid()
-	user.egid = syscall.Getegid()
-
-	return user
+	uid    int
+	gid    int
+	euid   int
+	egid   int
+	groups []int
 }
 
 // GetUID retrive the user's id
@@ -46,3 +28,8 @@ func (u UserDetails) GetEUid() int {
 func (u UserDetails) GetEGid() int {
 	return u.egid
 }
+
+// GetGroups retrive the supplementary group ids the user belongs to
+func (u UserDetails) GetGroups() []int {
+	return u.groups
+}
@@ -0,0 +1,197 @@
+package fsutils
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WalkOptions controls how Walk traverses a directory tree
+type WalkOptions struct {
+	// FollowSymlinks resolves symbolic links encountered during the walk and
+	// descends into the target if it is a directory, instead of yielding the
+	// link itself
+	FollowSymlinks bool
+
+	// SkipUnreadable silently skips entries that IsReadable() reports as
+	// inaccessible to the current user, rather than passing them to fn
+	SkipUnreadable bool
+
+	// MaxDepth bounds how many directories deep the walk descends below
+	// root. A value <= 0 means unlimited depth
+	MaxDepth int
+
+	// IgnorePermissionErrors makes the walk continue past a directory it
+	// could not list because of a permission error, instead of aborting
+	IgnorePermissionErrors bool
+}
+
+// depth returns how many levels path lies below root: 0 for root itself, 1
+// for its direct children, and so on
+func depth(root, path string) int {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return strings.Count(rel, string(os.PathSeparator)) + 1
+}
+
+// Walk wraps filepath.WalkDir, yielding a fully-populated SystemPath for
+// every entry under root. When opts.FollowSymlinks is set, a symlink that
+// resolves to a directory is descended into as if it were a real
+// subdirectory, with the entries underneath it reported under the
+// symlink's own path rather than the resolved real path.
+func Walk(root string, opts WalkOptions, fn func(path string, sp SystemPath) error) error {
+	return walkDir(root, root, root, opts, map[string]bool{}, false, fn)
+}
+
+// walkDir is the shared core behind Walk and its symlink-following
+// recursion. realRoot is where filepath.WalkDir actually reads from;
+// reportRoot is the path entries under realRoot are remapped to before fn
+// is called, which differs from realRoot once a symlink has been followed.
+// origRoot anchors MaxDepth, which is always counted from the top-level
+// root a caller passed to Walk, not from a resolved symlink target. seen
+// guards against symlink cycles across distinct directories. skipRootFn is
+// set on the recursive calls made when following a symlink into a
+// directory, since the caller already passed that directory's own entry
+// (as the symlink) to fn before recursing.
+func walkDir(reportRoot, realRoot, origRoot string, opts WalkOptions, seen map[string]bool, skipRootFn bool, fn func(path string, sp SystemPath) error) error {
+	if seen[realRoot] {
+		return nil
+	}
+	seen[realRoot] = true
+
+	baseDepth := depth(origRoot, reportRoot)
+
+	return filepath.WalkDir(realRoot, func(rp string, d fs.DirEntry, err error) error {
+		reportPath := reportRoot
+		if rp != realRoot {
+			rel, relErr := filepath.Rel(realRoot, rp)
+			if relErr != nil {
+				return relErr
+			}
+			reportPath = filepath.Join(reportRoot, rel)
+		}
+
+		if err != nil {
+			if opts.IgnorePermissionErrors && os.IsPermission(err) {
+				return nil
+			}
+			return err
+		}
+
+		if skipRootFn && rp == realRoot {
+			return nil
+		}
+
+		if opts.MaxDepth > 0 && baseDepth+depth(realRoot, rp) > opts.MaxDepth {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		sp := SystemInitNoFollow(rp)
+
+		if opts.FollowSymlinks && sp.IsSymlink() {
+			resolved, rerr := sp.ResolveAll(40)
+			if rerr != nil {
+				if opts.IgnorePermissionErrors && os.IsPermission(rerr) {
+					return nil
+				}
+				return rerr
+			}
+			sp = resolved
+
+			if opts.SkipUnreadable && !sp.IsReadable() {
+				return nil
+			}
+
+			if err := fn(reportPath, sp); err != nil {
+				return err
+			}
+
+			if sp.IsDir() {
+				return walkDir(reportPath, sp.path, origRoot, opts, seen, true, fn)
+			}
+
+			return nil
+		}
+
+		if opts.SkipUnreadable && !sp.IsReadable() {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		return fn(reportPath, sp)
+	})
+}
+
+// walkErrors collects every problem ValidateReadable encounters so the
+// caller gets a single report instead of aborting on the first bad path
+type walkErrors struct {
+	errs []string
+}
+
+func (w *walkErrors) add(path string, reason string) {
+	w.errs = append(w.errs, fmt.Sprintf("%s: %s", path, reason))
+}
+
+func (w *walkErrors) asError() error {
+	if len(w.errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("unreadable paths found:\n%s", strings.Join(w.errs, "\n"))
+}
+
+// ValidateReadable walks every entry under root and returns a combined error
+// listing paths that are not readable by the current user, mirroring the
+// pattern used to validate a build/docker context directory before use.
+// Broken symlinks are skipped rather than reported, since a dangling link
+// is not a permission problem. A directory that cannot be listed because of
+// its own permissions is recorded like any other unreadable path, and the
+// walk continues into the rest of the tree instead of aborting.
+func ValidateReadable(root string) error {
+	result := &walkErrors{}
+
+	opts := WalkOptions{
+		FollowSymlinks:         false,
+		IgnorePermissionErrors: true,
+	}
+
+	err := Walk(root, opts, func(path string, sp SystemPath) error {
+		if sp.IsSymlink() {
+			if _, err := sp.ResolveAll(40); err != nil {
+				return nil
+			}
+		}
+
+		if !sp.IsReadable() {
+			result.add(path, "not readable by current user")
+			return nil
+		}
+
+		if sp.IsRegularFile() {
+			f, err := os.Open(path)
+			if err != nil {
+				if os.IsPermission(err) {
+					result.add(path, err.Error())
+					return nil
+				}
+				return err
+			}
+			f.Close()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return result.asError()
+}
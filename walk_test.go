@@ -0,0 +1,101 @@
+//go:build !windows
+// +build !windows
+
+package fsutils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateReadableContinuesPastUnreadableSubtree(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("requires a non-root user to exercise a denied permission")
+	}
+
+	root := t.TempDir()
+	blocked := filepath.Join(root, "aaa_blocked")
+	after := filepath.Join(root, "zzz_after")
+
+	if err := os.Mkdir(blocked, 0000); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chmod(blocked, 0755) })
+
+	if err := os.WriteFile(after, []byte("hi"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	var visitedAfter bool
+	err := Walk(root, WalkOptions{IgnorePermissionErrors: true}, func(path string, sp SystemPath) error {
+		if path == after {
+			visitedAfter = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if !visitedAfter {
+		t.Error("Walk() stopped before reaching a sibling after the unreadable directory")
+	}
+
+	verr := ValidateReadable(root)
+	if verr == nil {
+		t.Fatal("ValidateReadable() = nil, want an error reporting the unreadable directory")
+	}
+	if !strings.Contains(verr.Error(), blocked) {
+		t.Errorf("ValidateReadable() error = %q, want it to mention %q", verr.Error(), blocked)
+	}
+}
+
+func TestWalkFollowsSymlinkIntoDirectory(t *testing.T) {
+	root := t.TempDir()
+	real := filepath.Join(root, "real")
+	sub := filepath.Join(real, "sub")
+	file := filepath.Join(sub, "file.txt")
+	link := filepath.Join(root, "link")
+
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(file, []byte("hi"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.Symlink(real, link); err != nil {
+		t.Fatalf("Symlink: %v", err)
+	}
+
+	seen := map[string]bool{}
+	err := Walk(root, WalkOptions{FollowSymlinks: true}, func(path string, sp SystemPath) error {
+		seen[path] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+
+	want := filepath.Join(link, "sub", "file.txt")
+	if !seen[want] {
+		t.Errorf("Walk() did not visit %q through the followed symlink; saw %v", want, seen)
+	}
+
+	// The resolved directory's entry must not be reported twice: once as
+	// the symlink itself and once again as the first entry WalkDir yields
+	// when it descends into the resolved directory.
+	count := 0
+	err = Walk(root, WalkOptions{FollowSymlinks: true}, func(path string, sp SystemPath) error {
+		if path == link {
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Walk() reported %q %d times, want exactly 1", link, count)
+	}
+}